@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configEnvVar   = "SYNO_CONFIG"
+	configDirName  = "syno-iscsi"
+	configFileName = "config.yaml"
+
+	configReadMsg = "could not read config file %s: %s"
+)
+
+type config struct {
+	Thresholds thresholds `yaml:"thresholds"`
+}
+
+// thresholds are shared by every command which reports on capacity or health
+type thresholds struct {
+	VolumeFree     threshold `yaml:"volume_free"`     // percentage of the volume which is free
+	ThinOvercommit threshold `yaml:"thin_overcommit"` // ratio of the volume used if all thin LUNs were full
+	LunUsed        threshold `yaml:"lun_used"`        // percentage of a thin LUN which is allocated
+}
+
+type threshold struct {
+	Warning  float64 `yaml:"warning"`
+	Critical float64 `yaml:"critical"`
+}
+
+func defaultConfig() config {
+	return config{
+		Thresholds: thresholds{
+			VolumeFree:     threshold{Warning: 20, Critical: 10},
+			ThinOvercommit: threshold{Warning: 1.0, Critical: 1.5},
+			LunUsed:        threshold{Warning: 80, Critical: 90},
+		},
+	}
+}
+
+// above returns the state of a value where higher is worse
+func (t threshold) above(value float64) healthState {
+	if value > t.Critical {
+		return healthCritical
+	}
+	if value > t.Warning {
+		return healthWarning
+	}
+	return healthOK
+}
+
+// below returns the state of a value where lower is worse
+func (t threshold) below(value float64) healthState {
+	if value < t.Critical {
+		return healthCritical
+	}
+	if value < t.Warning {
+		return healthWarning
+	}
+	return healthOK
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, configDirName, configFileName)
+}
+
+// loadConfig reads the config file, a missing file is only an error when it was explicitly given
+func loadConfig() error {
+	cfg = defaultConfig()
+
+	path := configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && configPath == "" {
+			return nil
+		}
+		return &errApp{fmt.Sprintf(configReadMsg, path, err.Error())}
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return &errApp{fmt.Sprintf(configReadMsg, path, err.Error())}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/SynologyOpenSource/synology-csi/pkg/dsm/webapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	var buffer bytes.Buffer
+	var dir string
+
+	BeforeEach(func() {
+		buffer = bytes.Buffer{}
+		out = &buffer
+		dir = GinkgoT().TempDir()
+		configPath = ""
+	})
+
+	AfterEach(func() {
+		configPath = ""
+	})
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("uses the defaults without a config file", func() {
+		Expect(loadConfig()).To(Succeed())
+		Expect(cfg).To(Equal(defaultConfig()))
+	})
+
+	It("returns an error for a missing config file given explicitly", func() {
+		configPath = filepath.Join(dir, "missing.yaml")
+		Expect(loadConfig()).To(MatchError(ContainSubstring("could not read config file")))
+	})
+
+	It("returns an error for an invalid config file", func() {
+		configPath = writeConfig("thresholds: [")
+		Expect(loadConfig()).To(MatchError(ContainSubstring("could not read config file")))
+	})
+
+	It("overrides only the configured thresholds", func() {
+		configPath = writeConfig("thresholds:\n  volume_free:\n    warning: 60\n")
+		Expect(loadConfig()).To(Succeed())
+
+		expected := defaultConfig()
+		expected.Thresholds.VolumeFree.Warning = 60
+		Expect(cfg).To(Equal(expected))
+	})
+
+	It("applies the thresholds to the health command", func() {
+		path := writeConfig("thresholds:\n  volume_free:\n    warning: 60\n    critical: 55\n  lun_used:\n    warning: 50\n")
+
+		thinLun := lun2
+		thinLun.Location = "/vol1"
+		thinLun.Status = "normal"
+		thinLun.Used = 3 * gb
+
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return []webapi.VolInfo{vol1}, nil
+			},
+			lunList: func() ([]webapi.LunInfo, error) {
+				return []webapi.LunInfo{thinLun}, nil
+			},
+		}
+
+		cmd := append([]string{"", "--config", path}, validCommand[1:]...)
+		Expect(app.Run(append(cmd, "health"))).To(MatchError(&errExit{2}))
+		Expect(buffer.String()).To(Equal("CRITICAL - volume /vol1 has 50% free; LUN lun2 is 60% used\n"))
+	})
+})
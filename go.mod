@@ -8,6 +8,7 @@ require (
 	github.com/onsi/gomega v1.27.6
 	github.com/urfave/cli/v2 v2.25.2-0.20230329144437-c0cc5c2f76cc
 	golang.org/x/term v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -24,5 +25,4 @@ require (
 	golang.org/x/sys v0.6.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
 	golang.org/x/tools v0.7.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
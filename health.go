@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SynologyOpenSource/synology-csi/pkg/dsm/webapi"
+	"github.com/pfrybar/syno-iscsi/syno"
+	"github.com/urfave/cli/v2"
+)
+
+// health states follow the Nagios plugin convention, the value is the exit code
+type healthState int
+
+const (
+	healthOK healthState = iota
+	healthWarning
+	healthCritical
+	healthUnknown
+)
+
+func (s healthState) String() string {
+	switch s {
+	case healthOK:
+		return "OK"
+	case healthWarning:
+		return "WARNING"
+	case healthCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+type healthProblem struct {
+	state   healthState
+	message string
+}
+
+type healthReport struct {
+	volumes  int
+	luns     int
+	problems []healthProblem
+}
+
+func (r *healthReport) add(state healthState, format string, a ...interface{}) {
+	r.problems = append(r.problems, healthProblem{state, fmt.Sprintf(format, a...)})
+}
+
+func (r *healthReport) state() healthState {
+	state := healthOK
+	for _, problem := range r.problems {
+		if problem.state > state {
+			state = problem.state
+		}
+	}
+
+	return state
+}
+
+// summary returns a single line, listing the most severe problems first
+func (r *healthReport) summary() string {
+	state := r.state()
+	if state == healthOK {
+		return fmt.Sprintf("%s - %d volume(s) and %d LUN(s) healthy", state, r.volumes, r.luns)
+	}
+
+	var messages []string
+	for s := healthCritical; s > healthOK; s-- {
+		for _, problem := range r.problems {
+			if problem.state == s {
+				messages = append(messages, problem.message)
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s - %s", state, strings.Join(messages, "; "))
+}
+
+var healthCmd = cli.Command{
+	Name:      "health",
+	Usage:     "check volume, thin provisioning and LUN health (exits 0/1/2 for OK/WARNING/CRITICAL)",
+	ArgsUsage: " ",
+	Action: func(ctx *cli.Context) error {
+		if err := verifyArgs(0, ctx); err != nil {
+			return err
+		}
+
+		report, err := checkHealth(ctx)
+		if err != nil {
+			fmt.Fprintf(out, "%s - %s\n", healthUnknown, err.Error())
+			return &errExit{int(healthUnknown)}
+		}
+
+		fmt.Fprintln(out, report.summary())
+
+		if state := report.state(); state != healthOK {
+			return &errExit{int(state)}
+		}
+
+		return nil
+	},
+}
+
+func checkHealth(ctx *cli.Context) (*healthReport, error) {
+	if err := initAndLogin(ctx); err != nil {
+		return nil, err
+	}
+	defer logout()
+
+	volumes, err := synoClient.VolumeList()
+	if err != nil {
+		return nil, err
+	}
+
+	luns, err := synoClient.LunList()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &healthReport{volumes: len(volumes), luns: len(luns)}
+
+	for _, volume := range volumes {
+		checkVolumeHealth(report, volume, luns)
+	}
+
+	for _, lun := range luns {
+		checkLunHealth(report, lun)
+	}
+
+	return report, nil
+}
+
+func checkVolumeHealth(report *healthReport, volume webapi.VolInfo, luns []webapi.LunInfo) {
+	switch volume.Status {
+	case "normal":
+	case "crashed":
+		report.add(healthCritical, "volume %s is %s", volume.Path, volume.Status)
+	default:
+		report.add(healthWarning, "volume %s is %s", volume.Path, volume.Status)
+	}
+
+	size, err1 := strconv.ParseUint(volume.Size, 10, 64)
+	free, err2 := strconv.ParseUint(volume.Free, 10, 64)
+	if err1 != nil || err2 != nil || size == 0 {
+		report.add(healthWarning, "could not read the size of volume %s", volume.Path)
+		return
+	}
+
+	freePercent := float64(free) / float64(size) * 100
+	if state := cfg.Thresholds.VolumeFree.below(freePercent); state != healthOK {
+		report.add(state, "volume %s has %.0f%% free", volume.Path, freePercent)
+	}
+
+	// thin LUNs can still grow up to their full size, which has to fit in the volume
+	var thinGrowth uint64
+	for _, lun := range luns {
+		if lun.Location == volume.Path && syno.IsThin(lun.LunType) && lun.Size > lun.Used {
+			thinGrowth += lun.Size - lun.Used
+		}
+	}
+
+	overcommit := float64(size-free+thinGrowth) / float64(size)
+	if state := cfg.Thresholds.ThinOvercommit.above(overcommit); state != healthOK {
+		report.add(state, "thin LUNs on %s are over-committed (%.2fx)", volume.Path, overcommit)
+	}
+}
+
+func checkLunHealth(report *healthReport, lun webapi.LunInfo) {
+	switch lun.Status {
+	case "normal":
+	case "crashed":
+		report.add(healthCritical, "LUN %s is %s", lun.Name, lun.Status)
+	default:
+		report.add(healthWarning, "LUN %s is %s", lun.Name, lun.Status)
+	}
+
+	// thick LUNs are always fully allocated
+	if !syno.IsThin(lun.LunType) || lun.Size == 0 {
+		return
+	}
+
+	usedPercent := float64(lun.Used) / float64(lun.Size) * 100
+	if state := cfg.Thresholds.LunUsed.above(usedPercent); state != healthOK {
+		report.add(state, "LUN %s is %.0f%% used", lun.Name, usedPercent)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/SynologyOpenSource/synology-csi/pkg/dsm/webapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health", func() {
+	var buffer bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = bytes.Buffer{}
+		out = &buffer
+	})
+
+	runHealth := func() int {
+		cmd := append(validCommand, "health")
+		err := app.Run(cmd)
+		if err == nil {
+			return 0
+		}
+
+		var errExit *errExit
+		Expect(errors.As(err, &errExit)).To(BeTrue())
+		return errExit.code
+	}
+
+	It("returns an error with the wrong number of arguments", func() {
+		cmd := append(validCommand, "health", "none")
+		Expect(app.Run(cmd)).To(MatchError(fmt.Sprintf(notEnoughArgsMsg, 0, 1)))
+	})
+
+	It("reports OK when everything is healthy", func() {
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return []webapi.VolInfo{vol1}, nil
+			},
+			lunList: func() ([]webapi.LunInfo, error) {
+				return []webapi.LunInfo{lun1}, nil
+			},
+		}
+
+		Expect(runHealth()).To(Equal(0))
+		Expect(buffer.String()).To(Equal("OK - 1 volume(s) and 1 LUN(s) healthy\n"))
+	})
+
+	It("reports WARNING for degraded volumes and LUNs", func() {
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return []webapi.VolInfo{vol1, vol2}, nil
+			},
+			lunList: func() ([]webapi.LunInfo, error) {
+				return []webapi.LunInfo{lun1, lun2}, nil
+			},
+		}
+
+		Expect(runHealth()).To(Equal(1))
+		output := buffer.String()
+		Expect(output).To(HavePrefix("WARNING - "))
+		Expect(output).To(ContainSubstring("volume /vol2 is degraded"))
+		Expect(output).To(ContainSubstring("LUN lun2 is degraded"))
+	})
+
+	It("reports CRITICAL for full volumes, listing critical problems first", func() {
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return []webapi.VolInfo{vol2, vol3}, nil
+			},
+		}
+
+		Expect(runHealth()).To(Equal(2))
+		Expect(buffer.String()).To(Equal("CRITICAL - volume /vol3 has 0% free; volume /vol2 is degraded\n"))
+	})
+
+	It("reports over-committed thin LUNs", func() {
+		thinLun := lun2
+		thinLun.Location = "/vol1"
+		thinLun.Status = "normal"
+		thinLun.Size = 8 * gb
+
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return []webapi.VolInfo{vol1}, nil
+			},
+			lunList: func() ([]webapi.LunInfo, error) {
+				return []webapi.LunInfo{thinLun}, nil
+			},
+		}
+
+		Expect(runHealth()).To(Equal(1))
+		Expect(buffer.String()).To(Equal("WARNING - thin LUNs on /vol1 are over-committed (1.30x)\n"))
+	})
+
+	It("reports UNKNOWN when DSM cannot be queried", func() {
+		synoClient = &MockSynoClient{
+			volumeList: func() ([]webapi.VolInfo, error) {
+				return nil, errors.New("boom")
+			},
+		}
+
+		Expect(runHealth()).To(Equal(3))
+		Expect(buffer.String()).To(Equal("UNKNOWN - boom\n"))
+	})
+})
@@ -22,17 +22,24 @@ import (
 var (
 	out        io.Writer   = os.Stdout
 	in         io.Reader   = os.Stdin
-	synoClient syno.Client = &syno.DSMClient{}
+	synoClient syno.Client
+
+	// creates the client once the global flags are known, replaced in tests
+	newSynoClient = func(host string, opts ...syno.Option) syno.Client {
+		return syno.New(host, opts...)
+	}
+	cfg                    = defaultConfig()
 
 	// password should be masked, which the 'term' library handles
 	// can't use the global 'in' io.Reader since it wouldn't be masked
 	stdin = int(syscall.Stdin)
 
-	host  string
-	port  int
-	user  string
-	pass  string
-	https bool
+	host       string
+	port       int
+	user       string
+	pass       string
+	https      bool
+	configPath string
 
 	lunRegex = regexp.MustCompile("^[a-zA-Z0-9-]+$")
 )
@@ -78,8 +85,22 @@ func (e *errApp) Error() string {
 	return e.s
 }
 
+// errExit ends the program with a specific exit code, any output has already been printed
+type errExit struct {
+	code int
+}
+
+func (e *errExit) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 func main() {
 	if err := app.Run(os.Args); err != nil {
+		var errExit *errExit
+		if errors.As(err, &errExit) {
+			os.Exit(errExit.code)
+		}
+
 		var errApp *errApp
 		if errors.As(err, &errApp) {
 			fmt.Fprintf(out, "Error: %s\n", errApp.Error())
@@ -129,6 +150,15 @@ var app = &cli.App{
 			Destination: &https,
 			EnvVars:     []string{httpsEnvVar},
 		},
+		&cli.StringFlag{
+			Name:        "config",
+			Usage:       "path to the config file (default: syno-iscsi/config.yaml in the user config directory)",
+			Destination: &configPath,
+			EnvVars:     []string{configEnvVar},
+		},
+	},
+	Before: func(ctx *cli.Context) error {
+		return loadConfig()
 	},
 	Commands: []*cli.Command{
 		{
@@ -152,6 +182,7 @@ var app = &cli.App{
 				&targetListCmd, &targetCreateCmd, &targetDeleteCmd,
 			},
 		},
+		&healthCmd,
 	},
 }
 
@@ -309,14 +340,14 @@ var lunCreateCmd = cli.Command{
 			return &errApp{message}
 		}
 
-		devAttributes := []webapi.LunDevAttrib{}
+		devAttributes := []string{}
 
 		if reclaim {
-			devAttributes = append(devAttributes, syno.LUN_SPACE_RECLAMATION)
+			devAttributes = append(devAttributes, syno.DevAttribSpaceReclamation)
 		}
 
 		if syncCache {
-			devAttributes = append(devAttributes, syno.LUN_FUA_WRITE, syno.LUN_SYNC_CACHE)
+			devAttributes = append(devAttributes, syno.DevAttribFUAWrite, syno.DevAttribSyncCache)
 		}
 
 		lunType := syno.GetLunType(volume.FsType, thin)
@@ -325,7 +356,7 @@ var lunCreateCmd = cli.Command{
 			Location:   volumePath,
 			Size:       int64(size),
 			Type:       lunType,
-			DevAttribs: devAttributes,
+			DevAttribs: syno.EnableDevAttribs(devAttributes...),
 		}
 
 		_, err = synoClient.LunCreate(spec)
@@ -747,7 +778,11 @@ func initAndLogin(ctx *cli.Context) error {
 		}
 	}
 
-	synoClient.Init(host, port, user, pass, https)
+	synoClient = newSynoClient(host,
+		syno.WithPort(port),
+		syno.WithCredentials(user, pass),
+		syno.WithHTTPS(https),
+	)
 
 	if err := synoClient.Login(); err != nil {
 		// webapi.DSM() does not expose errors so have to manually parse the error string
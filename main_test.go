@@ -84,6 +84,11 @@ var target2 = webapi.TargetInfo{
 }
 
 func TestSynoIscsi(t *testing.T) {
+	// never pick up the config file of whoever is running the tests
+	configHome := t.TempDir()
+	t.Setenv("HOME", configHome)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "SynoiSCSI Suite")
 }
@@ -240,6 +245,7 @@ var _ = Describe("Main", func() {
 			Entry("runs 'target list'", "target", "list"),
 			Entry("runs 'target create ...'", "target", "create", "target1", "iqn.2000-01.com.synology:target1"),
 			Entry("runs 'target delete ...'", "target", "delete", "target1"),
+			Entry("runs 'health'", "health"),
 		)
 	})
 
@@ -0,0 +1,16 @@
+// Package syno is a small client for managing Synology iSCSI storage (volumes, LUNs and
+// targets) through the DSM web API. It is used by the syno-iscsi CLI and can be imported
+// by other tooling, the exported API follows semantic versioning.
+//
+// A client is created with New and must login before use:
+//
+//	client := syno.New("nas.local", syno.WithCredentials("admin", "secret"))
+//	if err := client.Login(); err != nil {
+//		return err
+//	}
+//	defer client.Logout()
+//
+//	luns, err := client.LunList()
+//
+// Requests and responses use the types from the webapi package of synology-csi.
+package syno
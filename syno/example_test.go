@@ -0,0 +1,54 @@
+package syno_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/SynologyOpenSource/synology-csi/pkg/dsm/webapi"
+	"github.com/pfrybar/syno-iscsi/syno"
+)
+
+func Example() {
+	client := syno.New("nas.local",
+		syno.WithPort(5001),
+		syno.WithHTTPS(true),
+		syno.WithCredentials("admin", "secret"),
+	)
+
+	if err := client.Login(); err != nil {
+		log.Fatal(err)
+	}
+	defer client.Logout()
+
+	luns, err := client.LunList()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, lun := range luns {
+		fmt.Println(lun.Name, lun.Location, syno.IsThin(lun.LunType))
+	}
+}
+
+func ExampleDSMClient_LunCreate() {
+	client := syno.New("nas.local", syno.WithCredentials("admin", "secret"))
+	if err := client.Login(); err != nil {
+		log.Fatal(err)
+	}
+	defer client.Logout()
+
+	spec := webapi.LunCreateSpec{
+		Name:       "vm-disk",
+		Location:   "/volume1",
+		Size:       10 * 1024 * 1024 * 1024,
+		Type:       syno.GetLunType("btrfs", true),
+		DevAttribs: syno.EnableDevAttribs(syno.DevAttribSpaceReclamation),
+	}
+
+	uuid, err := client.LunCreate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("created LUN", uuid)
+}
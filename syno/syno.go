@@ -2,10 +2,11 @@ package syno
 
 import "github.com/SynologyOpenSource/synology-csi/pkg/dsm/webapi"
 
-// matches some of the methods from webapi.DSM
-// Init is new, which allows the client to be initialised after creation
+// DefaultPort is the port on which DSM listens for http connections
+const DefaultPort = 5000
+
+// Client matches the methods from webapi.DSM which are needed to manage iSCSI storage
 type Client interface {
-	Init(host string, port int, user string, pass string, https bool)
 	Login() error
 	Logout() error
 	VolumeList() ([]webapi.VolInfo, error)
@@ -17,40 +18,75 @@ type Client interface {
 	LunDelete(lunUuid string) error
 	TargetList() ([]webapi.TargetInfo, error)
 	TargetCreate(spec webapi.TargetCreateSpec) (string, error)
-	TargetDelete(targetName string) error // webapi.DSM incorrect, this should be targetId
+	TargetDelete(targetId string) error
 }
 
+// DSMClient is a Client which talks to the DSM web API
 type DSMClient struct {
 	webapi.DSM
 }
 
-func (dc *DSMClient) Init(
-	host string,
-	port int,
-	user string,
-	pass string,
-	https bool,
-) {
-	dc.Ip = host
-	dc.Port = port
-	dc.Username = user
-	dc.Password = pass
-	dc.Https = https
+// Option configures a DSMClient created with New
+type Option func(*DSMClient)
+
+// WithPort sets the port on which DSM is listening, DefaultPort is used otherwise
+func WithPort(port int) Option {
+	return func(dc *DSMClient) {
+		dc.Port = port
+	}
+}
+
+// WithCredentials sets the user and password used to login
+func WithCredentials(user string, pass string) Option {
+	return func(dc *DSMClient) {
+		dc.Username = user
+		dc.Password = pass
+	}
+}
+
+// WithHTTPS enables https for the connection to DSM
+func WithHTTPS(https bool) Option {
+	return func(dc *DSMClient) {
+		dc.Https = https
+	}
 }
 
-var LUN_SPACE_RECLAMATION = webapi.LunDevAttrib{
-	DevAttrib: "emulate_tpu",
-	Enable:    1,
+// New creates a client for the DSM running on host, Login must be called before any other method
+func New(host string, opts ...Option) *DSMClient {
+	dc := &DSMClient{
+		DSM: webapi.DSM{
+			Ip:   host,
+			Port: DefaultPort,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(dc)
+	}
+
+	return dc
 }
 
-var LUN_FUA_WRITE = webapi.LunDevAttrib{
-	DevAttrib: "emulate_fua_write",
-	Enable:    1,
+// TargetDelete deletes the target with the given id, webapi.DSM incorrectly names it targetName
+func (dc *DSMClient) TargetDelete(targetId string) error {
+	return dc.DSM.TargetDelete(targetId)
 }
 
-var LUN_SYNC_CACHE = webapi.LunDevAttrib{
-	DevAttrib: "emulate_sync_cache",
-	Enable:    1,
+// dev attributes which can be enabled when creating a LUN
+const (
+	DevAttribSpaceReclamation = "emulate_tpu"
+	DevAttribFUAWrite         = "emulate_fua_write"
+	DevAttribSyncCache        = "emulate_sync_cache"
+)
+
+// EnableDevAttribs returns the given dev attributes in the form expected by webapi.LunCreateSpec
+func EnableDevAttribs(names ...string) []webapi.LunDevAttrib {
+	devAttribs := []webapi.LunDevAttrib{}
+	for _, name := range names {
+		devAttribs = append(devAttribs, webapi.LunDevAttrib{DevAttrib: name, Enable: 1})
+	}
+
+	return devAttribs
 }
 
 // Synology supports the following LUN types (and probably more):
@@ -59,6 +95,7 @@ var LUN_SYNC_CACHE = webapi.LunDevAttrib{
 // 259 - BTRFS thick, "BLUN_THICK"
 // 263 - BTRFS thin,  "BLUN"
 
+// IsThin returns whether the numeric LUN type from webapi.LunInfo is thin provisioned
 func IsThin(lunType int) bool {
 	switch lunType {
 	case 3:
@@ -74,6 +111,8 @@ func IsThin(lunType int) bool {
 	}
 }
 
+// GetLunType returns the LUN type to use in webapi.LunCreateSpec for a volume's filesystem,
+// or an empty string if the filesystem does not support LUNs
 func GetLunType(fsType string, thin bool) string {
 	switch fsType {
 	case "ext4":
@@ -40,3 +40,26 @@ func TestGetLunType(t *testing.T) {
 		}
 	}
 }
+
+func TestNew(t *testing.T) {
+	client := New("host")
+	if client.Ip != "host" || client.Port != DefaultPort || client.Https {
+		t.Errorf("New(\"host\") - unexpected defaults: %+v", client.DSM)
+	}
+
+	client = New("host", WithPort(1234), WithCredentials("user", "pass"), WithHTTPS(true))
+	if client.Port != 1234 || client.Username != "user" || client.Password != "pass" || !client.Https {
+		t.Errorf("New(\"host\", ...) - options not applied: %+v", client.DSM)
+	}
+}
+
+func TestEnableDevAttribs(t *testing.T) {
+	if devAttribs := EnableDevAttribs(); devAttribs == nil || len(devAttribs) != 0 {
+		t.Errorf("EnableDevAttribs() - expected an empty slice, got: %v", devAttribs)
+	}
+
+	devAttribs := EnableDevAttribs(DevAttribFUAWrite, DevAttribSyncCache)
+	if len(devAttribs) != 2 || devAttribs[0].DevAttrib != DevAttribFUAWrite || devAttribs[1].Enable != 1 {
+		t.Errorf("EnableDevAttribs(...) - unexpected result: %v", devAttribs)
+	}
+}